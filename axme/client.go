@@ -6,20 +6,63 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 )
 
 type ClientConfig struct {
 	BaseURL    string
 	APIKey     string
 	HTTPClient *http.Client
+
+	// Middlewares are appended after the SDK's built-in middlewares (auth,
+	// idempotency, trace propagation, logging), closest to the transport.
+	// Use them for cross-cutting concerns the built-ins don't cover, such as
+	// tenant routing headers, without forking the client.
+	Middlewares []Middleware
+
+	// Logger, when set, enables request/response logging with Authorization
+	// redacted. Left nil, the SDK logs nothing.
+	Logger *log.Logger
+
+	// Retry configures automatic retries of transient failures. Left at its
+	// zero value, retries are disabled.
+	Retry RetryConfig
+
+	// RateLimit caps the steady-state rate of outgoing requests. Left at
+	// its zero value, rate limiting is disabled.
+	RateLimit RateLimitConfig
+
+	// MaxConcurrent caps the number of in-flight requests via a semaphore.
+	// Zero (the default) leaves concurrency unbounded.
+	MaxConcurrent int
+
+	// Metrics, when set, receives counters tracking rate limiter and
+	// semaphore saturation.
+	Metrics MetricsObserver
+
+	// Observer, when set, receives a start/end event for every call, the
+	// hook point for wiring the client into Prometheus, OpenTelemetry, or
+	// any other observability backend.
+	Observer RequestObserver
 }
 
 type RequestOptions struct {
 	IdempotencyKey string
 	TraceID        string
+
+	// Deadline and Timeout bound the whole call, including every retry
+	// attempt. The effective deadline is the earliest of the parent
+	// context's own deadline, Deadline, and now+Timeout.
+	Deadline time.Time
+	Timeout  time.Duration
+
+	// PerAttemptTimeout, if set, additionally bounds each individual retry
+	// attempt rather than just the call as a whole.
+	PerAttemptTimeout time.Duration
 }
 
 type HTTPError struct {
@@ -35,6 +78,17 @@ type Client struct {
 	baseURL    string
 	apiKey     string
 	httpClient *http.Client
+	doer       Doer
+
+	limiter   *tokenBucketLimiter
+	semaphore chan struct{}
+	metrics   MetricsObserver
+	observer  RequestObserver
+
+	streamBaseDelay time.Duration
+	streamMaxDelay  time.Duration
+
+	defaultTimeout time.Duration
 }
 
 func NewClient(config ClientConfig) (*Client, error) {
@@ -53,78 +107,204 @@ func NewClient(config ClientConfig) (*Client, error) {
 		httpClient = &http.Client{}
 	}
 
+	var limiter *tokenBucketLimiter
+	if config.RateLimit.QPS > 0 {
+		limiter = newTokenBucketLimiter(config.RateLimit)
+	}
+
+	builtins := []Middleware{
+		authMiddleware(apiKey),
+		idempotencyMiddleware(),
+		traceMiddleware(),
+		retryMiddleware(config.Retry, limiter, config.Metrics),
+		loggingMiddleware(config.Logger),
+	}
+	middlewares := append(builtins, config.Middlewares...)
+
+	var semaphore chan struct{}
+	if config.MaxConcurrent > 0 {
+		semaphore = make(chan struct{}, config.MaxConcurrent)
+	}
+
+	streamBaseDelay, streamMaxDelay := effectiveBackoffBounds(config.Retry)
+
 	return &Client{
-		baseURL:    strings.TrimRight(baseURL, "/"),
-		apiKey:     apiKey,
-		httpClient: httpClient,
+		baseURL:         strings.TrimRight(baseURL, "/"),
+		apiKey:          apiKey,
+		httpClient:      httpClient,
+		doer:            chainMiddlewares(httpClient, middlewares),
+		limiter:         limiter,
+		semaphore:       semaphore,
+		metrics:         config.Metrics,
+		observer:        config.Observer,
+		streamBaseDelay: streamBaseDelay,
+		streamMaxDelay:  streamMaxDelay,
 	}, nil
 }
 
 func (c *Client) RegisterNick(
 	ctx context.Context,
-	payload map[string]any,
+	in RegisterNickRequest,
 	options RequestOptions,
-) (map[string]any, error) {
-	return c.requestJSON(ctx, http.MethodPost, "/v1/users/register-nick", nil, payload, options)
+) (*RegisterNickResponse, error) {
+	var out RegisterNickResponse
+	if err := c.requestJSON(ctx, "RegisterNick", http.MethodPost, "/v1/users/register-nick", nil, in, &out, options); err != nil {
+		return nil, err
+	}
+	return &out, nil
 }
 
 func (c *Client) CheckNick(
 	ctx context.Context,
 	nick string,
 	options RequestOptions,
-) (map[string]any, error) {
-	return c.requestJSON(
+) (*CheckNickResponse, error) {
+	var out CheckNickResponse
+	if err := c.requestJSON(
 		ctx,
+		"CheckNick",
 		http.MethodGet,
 		"/v1/users/check-nick",
 		map[string]string{"nick": nick},
 		nil,
+		&out,
 		options,
-	)
+	); err != nil {
+		return nil, err
+	}
+	return &out, nil
 }
 
 func (c *Client) RenameNick(
 	ctx context.Context,
-	payload map[string]any,
+	in RenameNickRequest,
 	options RequestOptions,
-) (map[string]any, error) {
-	return c.requestJSON(ctx, http.MethodPost, "/v1/users/rename-nick", nil, payload, options)
+) (*RenameNickResponse, error) {
+	var out RenameNickResponse
+	if err := c.requestJSON(ctx, "RenameNick", http.MethodPost, "/v1/users/rename-nick", nil, in, &out, options); err != nil {
+		return nil, err
+	}
+	return &out, nil
 }
 
 func (c *Client) GetUserProfile(
 	ctx context.Context,
 	ownerAgent string,
 	options RequestOptions,
-) (map[string]any, error) {
-	return c.requestJSON(
+) (*UserProfile, error) {
+	var out UserProfile
+	if err := c.requestJSON(
 		ctx,
+		"GetUserProfile",
 		http.MethodGet,
 		"/v1/users/profile",
 		map[string]string{"owner_agent": ownerAgent},
 		nil,
+		&out,
 		options,
-	)
+	); err != nil {
+		return nil, err
+	}
+	return &out, nil
 }
 
 func (c *Client) UpdateUserProfile(
 	ctx context.Context,
-	payload map[string]any,
+	in UpdateUserProfileRequest,
 	options RequestOptions,
-) (map[string]any, error) {
-	return c.requestJSON(ctx, http.MethodPost, "/v1/users/profile/update", nil, payload, options)
+) (*UserProfile, error) {
+	var out UserProfile
+	if err := c.requestJSON(ctx, "UpdateUserProfile", http.MethodPost, "/v1/users/profile/update", nil, in, &out, options); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Do is the low-level, untyped escape hatch: it sends the request through
+// the same pipeline as the typed methods above. It marshals in (if non-nil)
+// as the JSON request body and decodes the
+// response into out (if non-nil), so callers can reach endpoints the SDK
+// hasn't added typed helpers for yet.
+func (c *Client) Do(ctx context.Context, method, path string, in any, out any, options RequestOptions) error {
+	return c.requestJSON(ctx, "Do", method, path, nil, in, out, options)
 }
 
 func (c *Client) requestJSON(
 	ctx context.Context,
+	operation string,
 	method string,
 	path string,
 	query map[string]string,
-	payload map[string]any,
+	payload any,
+	out any,
 	options RequestOptions,
-) (map[string]any, error) {
+) (err error) {
+	info := RequestInfo{Operation: operation, Method: method, Path: path}
+
+	var request *http.Request
+	var response *http.Response
+	var requestBytes, responseBytes int
+
+	ctx, cancel := c.effectiveContext(ctx, options)
+	defer cancel()
+	if options.PerAttemptTimeout > 0 {
+		ctx = withPerAttemptTimeout(ctx, options.PerAttemptTimeout)
+	}
+
+	ctx, counter := withAttemptCounter(ctx)
+	if c.observer != nil {
+		ctx = c.observer.OnRequestStart(ctx, info)
+	}
+	start := time.Now()
+	defer func() {
+		if c.observer == nil {
+			return
+		}
+		idempotencyKey := options.IdempotencyKey
+		if request != nil {
+			idempotencyKey = request.Header.Get("Idempotency-Key")
+		}
+		statusCode := 0
+		if response != nil {
+			statusCode = response.StatusCode
+		}
+		c.observer.OnRequestEnd(ctx, RequestResult{
+			RequestInfo:    info,
+			StatusCode:     statusCode,
+			Err:            err,
+			Duration:       time.Since(start),
+			RetryCount:     counter.attempts,
+			IdempotencyKey: idempotencyKey,
+			RequestBytes:   requestBytes,
+			ResponseBytes:  responseBytes,
+		})
+	}()
+
+	if c.semaphore != nil {
+		select {
+		case c.semaphore <- struct{}{}:
+			defer func() { <-c.semaphore }()
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	if c.limiter != nil {
+		waited, err := c.limiter.Wait(ctx)
+		if err != nil {
+			return err
+		}
+		if c.metrics != nil {
+			c.metrics.OnAcquired()
+			if waited > 0 {
+				c.metrics.OnWaited(waited)
+			}
+		}
+	}
+
 	endpointURL, err := url.Parse(c.baseURL + path)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	if len(query) > 0 {
@@ -141,17 +321,17 @@ func (c *Client) requestJSON(
 	if payload != nil {
 		encoded, err := json.Marshal(payload)
 		if err != nil {
-			return nil, err
+			return err
 		}
+		requestBytes = len(encoded)
 		body = bytes.NewReader(encoded)
 	}
 
-	request, err := http.NewRequestWithContext(ctx, method, endpointURL.String(), body)
+	request, err = http.NewRequestWithContext(ctx, method, endpointURL.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	request.Header.Set("Authorization", "Bearer "+c.apiKey)
 	request.Header.Set("Accept", "application/json")
 	if payload != nil {
 		request.Header.Set("Content-Type", "application/json")
@@ -163,28 +343,38 @@ func (c *Client) requestJSON(
 		request.Header.Set("X-Trace-Id", options.TraceID)
 	}
 
-	response, err := c.httpClient.Do(request)
+	// Authorization, a fallback Idempotency-Key, trace propagation, and
+	// logging all happen in the middleware chain so callers can extend or
+	// override them without touching requestJSON.
+	response, err = c.doer.Do(request)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer response.Body.Close()
 
 	responseBody, err := io.ReadAll(response.Body)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	responseBytes = len(responseBody)
+
+	if response.StatusCode == http.StatusTooManyRequests {
+		throttleLimiter(c.limiter, c.metrics, response.Header.Get("Retry-After"))
 	}
 
 	if response.StatusCode < 200 || response.StatusCode >= 300 {
-		return nil, &HTTPError{StatusCode: response.StatusCode, Body: string(responseBody)}
+		return &HTTPError{StatusCode: response.StatusCode, Body: string(responseBody)}
 	}
 
-	if len(responseBody) == 0 {
-		return map[string]any{}, nil
+	if out == nil || len(responseBody) == 0 {
+		return nil
 	}
 
-	var out map[string]any
-	if err := json.Unmarshal(responseBody, &out); err != nil {
-		return nil, err
+	if err := json.Unmarshal(responseBody, out); err != nil {
+		return err
+	}
+	if setter, ok := out.(rawJSONSetter); ok {
+		setter.setRawJSON(json.RawMessage(responseBody))
 	}
-	return out, nil
+	return nil
 }
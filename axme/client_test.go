@@ -47,14 +47,17 @@ func TestRegisterNick(t *testing.T) {
 
 	response, err := client.RegisterNick(
 		context.Background(),
-		map[string]any{"nick": "@partner.user", "display_name": "Partner User"},
+		RegisterNickRequest{Nick: "@partner.user", DisplayName: "Partner User"},
 		RequestOptions{IdempotencyKey: "register-1"},
 	)
 	if err != nil {
 		t.Fatalf("register nick failed: %v", err)
 	}
-	if response["ok"] != true {
-		t.Fatalf("unexpected response: %v", response)
+	if !response.OK {
+		t.Fatalf("unexpected response: %+v", response)
+	}
+	if len(response.RawJSON) == 0 {
+		t.Fatalf("expected RawJSON to be populated")
 	}
 }
 
@@ -71,11 +74,11 @@ func TestCheckNick(t *testing.T) {
 		}
 
 		_ = json.NewEncoder(w).Encode(map[string]any{
-			"ok":             true,
-			"nick":           "@partner.user",
+			"ok":              true,
+			"nick":            "@partner.user",
 			"normalized_nick": "partner.user",
-			"public_address": "partner.user@ax",
-			"available":      true,
+			"public_address":  "partner.user@ax",
+			"available":       true,
 		})
 	}))
 	defer server.Close()
@@ -89,8 +92,8 @@ func TestCheckNick(t *testing.T) {
 	if err != nil {
 		t.Fatalf("check nick failed: %v", err)
 	}
-	if response["available"] != true {
-		t.Fatalf("unexpected response: %v", response)
+	if !response.Available {
+		t.Fatalf("unexpected response: %+v", response)
 	}
 }
 
@@ -130,14 +133,14 @@ func TestRenameNick(t *testing.T) {
 
 	response, err := client.RenameNick(
 		context.Background(),
-		map[string]any{"owner_agent": "agent://user/1", "nick": "@partner.new"},
+		RenameNickRequest{OwnerAgent: "agent://user/1", Nick: "@partner.new"},
 		RequestOptions{IdempotencyKey: "rename-1"},
 	)
 	if err != nil {
 		t.Fatalf("rename nick failed: %v", err)
 	}
-	if response["nick"] != "@partner.new" {
-		t.Fatalf("unexpected response: %v", response)
+	if response.Nick != "@partner.new" {
+		t.Fatalf("unexpected response: %+v", response)
 	}
 }
 
@@ -171,8 +174,8 @@ func TestGetUserProfile(t *testing.T) {
 	if err != nil {
 		t.Fatalf("get profile failed: %v", err)
 	}
-	if response["owner_agent"] != "agent://user/1" {
-		t.Fatalf("unexpected response: %v", response)
+	if response.OwnerAgent != "agent://user/1" {
+		t.Fatalf("unexpected response: %+v", response)
 	}
 }
 
@@ -212,13 +215,33 @@ func TestUpdateUserProfile(t *testing.T) {
 
 	response, err := client.UpdateUserProfile(
 		context.Background(),
-		map[string]any{"owner_agent": "agent://user/1", "display_name": "Partner User Updated"},
+		UpdateUserProfileRequest{OwnerAgent: "agent://user/1", DisplayName: "Partner User Updated"},
 		RequestOptions{IdempotencyKey: "profile-1"},
 	)
 	if err != nil {
 		t.Fatalf("update profile failed: %v", err)
 	}
-	if response["display_name"] != "Partner User Updated" {
-		t.Fatalf("unexpected response: %v", response)
+	if response.DisplayName != "Partner User Updated" {
+		t.Fatalf("unexpected response: %+v", response)
+	}
+}
+
+func TestDoRawEscapeHatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"ok": true, "nick": "@partner.user"})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientConfig{BaseURL: server.URL, APIKey: "token", HTTPClient: server.Client()})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	var out map[string]any
+	if err := client.Do(context.Background(), http.MethodGet, "/v1/users/check-nick?nick=%40partner.user", nil, &out, RequestOptions{}); err != nil {
+		t.Fatalf("raw do failed: %v", err)
+	}
+	if out["nick"] != "@partner.user" {
+		t.Fatalf("unexpected response: %v", out)
 	}
 }
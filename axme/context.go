@@ -0,0 +1,43 @@
+package axme
+
+import "context"
+
+type contextKey string
+
+const (
+	traceIDContextKey     contextKey = "axme-trace-id"
+	traceParentContextKey contextKey = "axme-traceparent"
+)
+
+// WithTraceID returns a copy of ctx that carries traceID. Requests made with
+// the returned context propagate the trace ID via the X-Trace-Id header
+// whenever RequestOptions.TraceID isn't set explicitly.
+func WithTraceID(ctx context.Context, traceID string) context.Context {
+	return context.WithValue(ctx, traceIDContextKey, traceID)
+}
+
+// TraceIDFromContext returns the trace ID previously attached with
+// WithTraceID, if any.
+func TraceIDFromContext(ctx context.Context) (string, bool) {
+	traceID, ok := ctx.Value(traceIDContextKey).(string)
+	return traceID, ok && traceID != ""
+}
+
+// WithTraceParent returns a copy of ctx that carries a W3C traceparent
+// value. Requests made with the returned context send it via the
+// traceparent header, alongside X-Trace-Id, so a span an integrator started
+// elsewhere is visible to the server's tracing backend. The SDK has no
+// OpenTelemetry dependency of its own, so it can't derive this from ctx on
+// its own; integrators wire it up by formatting their active span context
+// (e.g. in a RequestObserver.OnRequestStart hook) and calling WithTraceParent
+// with the result.
+func WithTraceParent(ctx context.Context, traceParent string) context.Context {
+	return context.WithValue(ctx, traceParentContextKey, traceParent)
+}
+
+// TraceParentFromContext returns the traceparent previously attached with
+// WithTraceParent, if any.
+func TraceParentFromContext(ctx context.Context) (string, bool) {
+	traceParent, ok := ctx.Value(traceParentContextKey).(string)
+	return traceParent, ok && traceParent != ""
+}
@@ -0,0 +1,66 @@
+package axme
+
+import (
+	"context"
+	"time"
+)
+
+const perAttemptTimeoutContextKey contextKey = "axme-per-attempt-timeout"
+
+func withPerAttemptTimeout(ctx context.Context, d time.Duration) context.Context {
+	return context.WithValue(ctx, perAttemptTimeoutContextKey, d)
+}
+
+func perAttemptTimeoutFromContext(ctx context.Context) time.Duration {
+	d, _ := ctx.Value(perAttemptTimeoutContextKey).(time.Duration)
+	return d
+}
+
+// WithDefaultTimeout returns a shallow copy of c that applies d as the
+// default deadline for every call whose RequestOptions doesn't set its own
+// Deadline or Timeout. It shares the same underlying transport, rate
+// limiter, and semaphore as c.
+func (c *Client) WithDefaultTimeout(d time.Duration) *Client {
+	clone := *c
+	clone.defaultTimeout = d
+	return &clone
+}
+
+// effectiveContext derives the context requestJSON should use for the whole
+// call — including every retry attempt, since the overall budget applies to
+// the retry loop as a whole rather than per attempt — from the earliest of:
+// the parent context's own deadline, options.Deadline, options.Timeout (or
+// the client's default timeout if neither Deadline nor Timeout is set). The returned cancel
+// func must be called on every return path.
+func (c *Client) effectiveContext(ctx context.Context, options RequestOptions) (context.Context, context.CancelFunc) {
+	deadline, ok := earliestDeadline(ctx, options, c.defaultTimeout)
+	if !ok {
+		return context.WithCancel(ctx)
+	}
+	return context.WithDeadline(ctx, deadline)
+}
+
+func earliestDeadline(ctx context.Context, options RequestOptions, defaultTimeout time.Duration) (time.Time, bool) {
+	var deadline time.Time
+	have := false
+
+	if parent, ok := ctx.Deadline(); ok {
+		deadline, have = parent, true
+	}
+
+	if !options.Deadline.IsZero() && (!have || options.Deadline.Before(deadline)) {
+		deadline, have = options.Deadline, true
+	}
+
+	timeout := options.Timeout
+	if timeout <= 0 && options.Deadline.IsZero() {
+		timeout = defaultTimeout
+	}
+	if timeout > 0 {
+		if candidate := time.Now().Add(timeout); !have || candidate.Before(deadline) {
+			deadline, have = candidate, true
+		}
+	}
+
+	return deadline, have
+}
@@ -0,0 +1,77 @@
+package axme
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRequestOptionsTimeoutAborts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientConfig{BaseURL: server.URL, APIKey: "token", HTTPClient: server.Client()})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	_, err = client.CheckNick(context.Background(), "@partner.user", RequestOptions{Timeout: 5 * time.Millisecond})
+	if err == nil {
+		t.Fatal("expected the request to time out")
+	}
+}
+
+func TestWithDefaultTimeoutAppliesWhenUnset(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientConfig{BaseURL: server.URL, APIKey: "token", HTTPClient: server.Client()})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	client = client.WithDefaultTimeout(5 * time.Millisecond)
+
+	_, err = client.CheckNick(context.Background(), "@partner.user", RequestOptions{})
+	if err == nil {
+		t.Fatal("expected the default timeout to abort the request")
+	}
+}
+
+func TestRetryBudgetAppliesToWholeLoopNotPerAttempt(t *testing.T) {
+	var attempts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientConfig{
+		BaseURL:    server.URL,
+		APIKey:     "token",
+		HTTPClient: server.Client(),
+		Retry: RetryConfig{
+			MaxAttempts: 5,
+			BaseDelay:   30 * time.Millisecond,
+			MaxDelay:    30 * time.Millisecond,
+		},
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	_, err = client.CheckNick(context.Background(), "@partner.user", RequestOptions{Timeout: 20 * time.Millisecond})
+	if err == nil {
+		t.Fatal("expected the overall timeout to cut the retry loop short")
+	}
+	if attempts >= 5 {
+		t.Fatalf("expected the overall budget to cut retries short, got %d attempts", attempts)
+	}
+}
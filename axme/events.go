@@ -0,0 +1,204 @@
+package axme
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/AxmeAI/axme-sdk-go/axme/sse"
+)
+
+// UserEventFilter narrows which nick/profile events SubscribeUserEvents
+// delivers. Left at its zero value, every event is delivered.
+type UserEventFilter struct {
+	OwnerAgent string
+	Types      []string
+}
+
+// UserEvent is a single nick registration, rename, or profile update event
+// delivered over the subscription.
+type UserEvent struct {
+	ID         string
+	Type       string
+	OwnerAgent string
+	Nick       string
+	RawJSON    json.RawMessage
+}
+
+// SubscribeUserEvents opens a long-lived subscription to nick registration,
+// rename, and profile update events, instead of partners having to poll.
+// It automatically reconnects on failure using the same backoff policy as
+// the retry subsystem, resuming from the last event ID seen via
+// Last-Event-ID. The returned channels are closed once ctx is done; a fatal
+// authentication failure is sent on the error channel as a terminal error.
+// Delivery is at-least-once: a reconnect can redeliver an event the caller
+// already saw if the server re-sends it after the last acknowledged ID.
+func (c *Client) SubscribeUserEvents(ctx context.Context, filter UserEventFilter) (<-chan UserEvent, <-chan error, error) {
+	events := make(chan UserEvent)
+	errs := make(chan error, 1)
+
+	query := map[string]string{}
+	if strings.TrimSpace(filter.OwnerAgent) != "" {
+		query["owner_agent"] = filter.OwnerAgent
+	}
+	if len(filter.Types) > 0 {
+		query["types"] = strings.Join(filter.Types, ",")
+	}
+
+	go c.streamUserEvents(ctx, query, events, errs)
+
+	return events, errs, nil
+}
+
+func (c *Client) streamUserEvents(ctx context.Context, query map[string]string, events chan<- UserEvent, errs chan<- error) {
+	defer close(events)
+	defer close(errs)
+
+	lastEventID := ""
+	attempt := 0
+
+	for {
+		attempt++
+
+		response, err := c.openEventStream(ctx, query, lastEventID)
+		if err != nil {
+			if isFatalAuthError(err) {
+				errs <- err
+				return
+			}
+			if !c.waitForReconnect(ctx, attempt) {
+				return
+			}
+			continue
+		}
+		attempt = 0
+
+		lastEventID = c.drainEventStream(ctx, response, lastEventID, events)
+
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+		if !c.waitForReconnect(ctx, attempt+1) {
+			return
+		}
+	}
+}
+
+// drainEventStream reads events from response until the stream ends or ctx
+// is done, returning the last event ID seen so the caller can resume from
+// it on reconnect.
+func (c *Client) drainEventStream(ctx context.Context, response *http.Response, lastEventID string, events chan<- UserEvent) string {
+	defer response.Body.Close()
+
+	reader := sse.NewReader(response.Body)
+	for {
+		event, err := reader.Next()
+		if err != nil {
+			if id := reader.LastEventID(); id != "" {
+				return id
+			}
+			return lastEventID
+		}
+		if event.ID != "" {
+			lastEventID = event.ID
+		}
+
+		userEvent, ok := decodeUserEvent(event)
+		if !ok {
+			continue
+		}
+
+		select {
+		case events <- userEvent:
+		case <-ctx.Done():
+			return lastEventID
+		}
+	}
+}
+
+func decodeUserEvent(event sse.Event) (UserEvent, bool) {
+	if strings.TrimSpace(event.Data) == "" {
+		return UserEvent{}, false
+	}
+
+	var payload struct {
+		OwnerAgent string `json:"owner_agent"`
+		Nick       string `json:"nick"`
+	}
+	_ = json.Unmarshal([]byte(event.Data), &payload)
+
+	return UserEvent{
+		ID:         event.ID,
+		Type:       event.Event,
+		OwnerAgent: payload.OwnerAgent,
+		Nick:       payload.Nick,
+		RawJSON:    json.RawMessage(event.Data),
+	}, true
+}
+
+// openEventStream issues the subscribe request through the same middleware
+// chain as every other call (so auth, trace propagation, and logging still
+// apply) but, unlike requestJSON, hands back the live response body instead
+// of buffering it.
+func (c *Client) openEventStream(ctx context.Context, query map[string]string, lastEventID string) (*http.Response, error) {
+	endpointURL, err := url.Parse(c.baseURL + "/v1/users/events")
+	if err != nil {
+		return nil, err
+	}
+	if len(query) > 0 {
+		params := endpointURL.Query()
+		for k, v := range query {
+			if strings.TrimSpace(v) != "" {
+				params.Set(k, v)
+			}
+		}
+		endpointURL.RawQuery = params.Encode()
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, endpointURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Accept", "text/event-stream")
+	if lastEventID != "" {
+		request.Header.Set("Last-Event-ID", lastEventID)
+	}
+
+	response, err := c.doer.Do(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		defer response.Body.Close()
+		body, _ := io.ReadAll(response.Body)
+		return nil, &HTTPError{StatusCode: response.StatusCode, Body: string(body)}
+	}
+
+	return response, nil
+}
+
+func (c *Client) waitForReconnect(ctx context.Context, attempt int) bool {
+	delay := backoffWithFullJitter(c.streamBaseDelay, c.streamMaxDelay, attempt)
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(delay):
+		return true
+	}
+}
+
+func isFatalAuthError(err error) bool {
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode == http.StatusUnauthorized || httpErr.StatusCode == http.StatusForbidden
+	}
+	return false
+}
@@ -0,0 +1,150 @@
+package axme
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSubscribeUserEventsDeliversEvents(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Accept"); got != "text/event-stream" {
+			t.Errorf("unexpected accept header: %s", got)
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		flusher := w.(http.Flusher)
+
+		_, _ = w.Write([]byte("id: 1\nevent: nick.renamed\ndata: {\"owner_agent\":\"agent://user/1\",\"nick\":\"@partner.new\"}\n\n"))
+		flusher.Flush()
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientConfig{BaseURL: server.URL, APIKey: "token", HTTPClient: server.Client()})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, errs, err := client.SubscribeUserEvents(ctx, UserEventFilter{OwnerAgent: "agent://user/1"})
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+
+	select {
+	case event := <-events:
+		if event.Type != "nick.renamed" || event.Nick != "@partner.new" {
+			t.Fatalf("unexpected event: %+v", event)
+		}
+	case err := <-errs:
+		t.Fatalf("unexpected error: %v", err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for event")
+	}
+
+	cancel()
+}
+
+func TestSubscribeUserEventsSurfacesFatalAuthError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientConfig{BaseURL: server.URL, APIKey: "token", HTTPClient: server.Client()})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, errs, err := client.SubscribeUserEvents(ctx, UserEventFilter{})
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+
+	select {
+	case event := <-events:
+		t.Fatalf("unexpected event: %+v", event)
+	case err := <-errs:
+		if err == nil {
+			t.Fatal("expected a non-nil terminal error")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for terminal error")
+	}
+}
+
+func TestSubscribeUserEventsResumesLastEventIDAfterEmptyReconnect(t *testing.T) {
+	var connection int32
+	headers := make(chan string, 3)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&connection, 1)
+		headers <- r.Header.Get("Last-Event-ID")
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+
+		if n == 1 {
+			flusher := w.(http.Flusher)
+			_, _ = w.Write([]byte("id: 1\nevent: nick.renamed\ndata: {\"nick\":\"@first\"}\n\n"))
+			flusher.Flush()
+		}
+		// Connection 2 (and beyond) closes immediately without writing
+		// anything, simulating a reconnect that dies before it sees a
+		// fresh id: field.
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientConfig{
+		BaseURL:    server.URL,
+		APIKey:     "token",
+		HTTPClient: server.Client(),
+		Retry:      RetryConfig{BaseDelay: 5 * time.Millisecond, MaxDelay: 10 * time.Millisecond},
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, errs, err := client.SubscribeUserEvents(ctx, UserEventFilter{})
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+
+	select {
+	case event := <-events:
+		if event.Nick != "@first" {
+			t.Fatalf("unexpected event: %+v", event)
+		}
+	case err := <-errs:
+		t.Fatalf("unexpected error: %v", err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for first event")
+	}
+
+	if got := <-headers; got != "" {
+		t.Fatalf("unexpected Last-Event-ID on first connection: %q", got)
+	}
+	if got := <-headers; got != "1" {
+		t.Fatalf("unexpected Last-Event-ID on second connection: %q", got)
+	}
+
+	select {
+	case got := <-headers:
+		if got != "1" {
+			t.Fatalf("expected Last-Event-ID to survive an empty reconnect, got %q", got)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for third connection")
+	}
+}
@@ -0,0 +1,24 @@
+package axme
+
+import (
+	"crypto/rand"
+	"fmt"
+)
+
+// newUUIDv4 generates a random (version 4, variant 1) UUID without pulling
+// in an external dependency. It is used as a fallback identifier whenever a
+// caller doesn't supply one of their own (e.g. an idempotency key).
+func newUUIDv4() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		// crypto/rand failing is effectively unrecoverable; fall back to a
+		// fixed, well-formed v4 UUID rather than panicking. Every value
+		// returned on this path is the same.
+		return "00000000-0000-4000-8000-000000000000"
+	}
+
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -0,0 +1,123 @@
+package axme
+
+import (
+	"log"
+	"net/http"
+	"strings"
+)
+
+// Doer performs a single HTTP round trip. *http.Client satisfies it, which
+// makes it a convenient seam for tests and for composing middlewares.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Middleware wraps a Doer with additional behavior, the same shape as the
+// filter/handler chains used by service-broker style HTTP frameworks.
+// Middlewares are applied in the order they appear in
+// ClientConfig.Middlewares: the first one is the outermost layer and sees
+// the request before anything else does.
+type Middleware func(next Doer) Doer
+
+type doerFunc func(req *http.Request) (*http.Response, error)
+
+func (f doerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// chainMiddlewares builds a single Doer out of base plus every middleware in
+// middlewares, preserving the order documented on Middleware.
+func chainMiddlewares(base Doer, middlewares []Middleware) Doer {
+	doer := base
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		doer = middlewares[i](doer)
+	}
+	return doer
+}
+
+// authMiddleware injects the bearer token on every outgoing request unless
+// the caller already set an Authorization header themselves.
+func authMiddleware(apiKey string) Middleware {
+	return func(next Doer) Doer {
+		return doerFunc(func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get("Authorization") == "" {
+				req.Header.Set("Authorization", "Bearer "+apiKey)
+			}
+			return next.Do(req)
+		})
+	}
+}
+
+// idempotencyMiddleware fills in a random Idempotency-Key whenever the
+// caller didn't request a specific one via RequestOptions.IdempotencyKey.
+func idempotencyMiddleware() Middleware {
+	return func(next Doer) Doer {
+		return doerFunc(func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get("Idempotency-Key") == "" {
+				req.Header.Set("Idempotency-Key", newUUIDv4())
+			}
+			return next.Do(req)
+		})
+	}
+}
+
+// traceMiddleware propagates a trace ID attached to the request's context
+// via WithTraceID, unless RequestOptions.TraceID already set the header
+// explicitly, and injects the W3C traceparent header whenever one was
+// attached via WithTraceParent. It does not derive traceparent from ctx on
+// its own — see WithTraceParent's doc comment.
+func traceMiddleware() Middleware {
+	return func(next Doer) Doer {
+		return doerFunc(func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get("X-Trace-Id") == "" {
+				if traceID, ok := TraceIDFromContext(req.Context()); ok {
+					req.Header.Set("X-Trace-Id", traceID)
+				}
+			}
+			if traceParent, ok := TraceParentFromContext(req.Context()); ok {
+				req.Header.Set("traceparent", traceParent)
+			}
+			return next.Do(req)
+		})
+	}
+}
+
+// redactedHeaders is the set of header names loggingMiddleware never prints
+// verbatim.
+var redactedHeaders = map[string]string{
+	"Authorization": "[REDACTED]",
+}
+
+// loggingMiddleware logs the method/URL/status of every request through the
+// chain. It is a no-op when logger is nil, so it's safe to always install.
+func loggingMiddleware(logger *log.Logger) Middleware {
+	return func(next Doer) Doer {
+		if logger == nil {
+			return next
+		}
+		return doerFunc(func(req *http.Request) (*http.Response, error) {
+			logger.Printf("axme: -> %s %s %s", req.Method, req.URL.String(), redactHeaders(req.Header))
+
+			resp, err := next.Do(req)
+			if err != nil {
+				logger.Printf("axme: <- %s %s error: %v", req.Method, req.URL.String(), err)
+				return resp, err
+			}
+
+			logger.Printf("axme: <- %s %s %d", req.Method, req.URL.String(), resp.StatusCode)
+			return resp, err
+		})
+	}
+}
+
+func redactHeaders(header http.Header) string {
+	parts := make([]string, 0, len(header))
+	for name, values := range header {
+		if redacted, ok := redactedHeaders[name]; ok {
+			parts = append(parts, name+"="+redacted)
+			continue
+		}
+		parts = append(parts, name+"="+strings.Join(values, ","))
+	}
+	return strings.Join(parts, " ")
+}
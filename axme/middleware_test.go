@@ -0,0 +1,62 @@
+package axme
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientAppliesCustomMiddleware(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Tenant-Id"); got != "tenant-42" {
+			t.Fatalf("unexpected tenant header: %s", got)
+		}
+		if got := r.Header.Get("Idempotency-Key"); got == "" {
+			t.Fatalf("expected a fallback idempotency key to be generated")
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	tenantMiddleware := func(next Doer) Doer {
+		return doerFunc(func(req *http.Request) (*http.Response, error) {
+			req.Header.Set("X-Tenant-Id", "tenant-42")
+			return next.Do(req)
+		})
+	}
+
+	client, err := NewClient(ClientConfig{
+		BaseURL:     server.URL,
+		APIKey:      "token",
+		HTTPClient:  server.Client(),
+		Middlewares: []Middleware{tenantMiddleware},
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	if _, err := client.CheckNick(context.Background(), "@partner.user", RequestOptions{}); err != nil {
+		t.Fatalf("check nick failed: %v", err)
+	}
+}
+
+func TestTraceMiddlewarePropagatesContextTraceID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Trace-Id"); got != "trace-from-ctx" {
+			t.Fatalf("unexpected trace header: %s", got)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientConfig{BaseURL: server.URL, APIKey: "token", HTTPClient: server.Client()})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	ctx := WithTraceID(context.Background(), "trace-from-ctx")
+	if _, err := client.CheckNick(ctx, "@partner.user", RequestOptions{}); err != nil {
+		t.Fatalf("check nick failed: %v", err)
+	}
+}
@@ -0,0 +1,75 @@
+package axme
+
+import "encoding/json"
+
+// ResponseMeta is embedded in every typed response so callers always have
+// access to the raw payload the server returned, even for fields the SDK
+// doesn't know about yet.
+type ResponseMeta struct {
+	RawJSON json.RawMessage `json:"-"`
+}
+
+func (m *ResponseMeta) setRawJSON(raw json.RawMessage) {
+	m.RawJSON = raw
+}
+
+// rawJSONSetter lets requestJSON stash the raw response body on any out
+// value that embeds ResponseMeta, without needing to know its concrete type.
+type rawJSONSetter interface {
+	setRawJSON(json.RawMessage)
+}
+
+type RegisterNickRequest struct {
+	Nick        string `json:"nick"`
+	DisplayName string `json:"display_name,omitempty"`
+}
+
+type RegisterNickResponse struct {
+	ResponseMeta
+
+	OK         bool   `json:"ok"`
+	UserID     string `json:"user_id"`
+	OwnerAgent string `json:"owner_agent"`
+	Nick       string `json:"nick"`
+}
+
+type CheckNickResponse struct {
+	ResponseMeta
+
+	OK             bool   `json:"ok"`
+	Nick           string `json:"nick"`
+	NormalizedNick string `json:"normalized_nick"`
+	PublicAddress  string `json:"public_address"`
+	Available      bool   `json:"available"`
+}
+
+type RenameNickRequest struct {
+	OwnerAgent string `json:"owner_agent"`
+	Nick       string `json:"nick"`
+}
+
+type RenameNickResponse struct {
+	ResponseMeta
+
+	OK         bool   `json:"ok"`
+	UserID     string `json:"user_id"`
+	OwnerAgent string `json:"owner_agent"`
+	Nick       string `json:"nick"`
+}
+
+type UpdateUserProfileRequest struct {
+	OwnerAgent  string `json:"owner_agent"`
+	DisplayName string `json:"display_name,omitempty"`
+}
+
+// UserProfile is returned by GetUserProfile, and by UpdateUserProfile with
+// whichever fields were changed populated.
+type UserProfile struct {
+	ResponseMeta
+
+	OK          bool   `json:"ok"`
+	UserID      string `json:"user_id"`
+	OwnerAgent  string `json:"owner_agent"`
+	Nick        string `json:"nick,omitempty"`
+	DisplayName string `json:"display_name,omitempty"`
+}
@@ -0,0 +1,56 @@
+package axme
+
+import (
+	"context"
+	"time"
+)
+
+// RequestObserver is the hook point for wiring the client into an
+// observability backend — Prometheus counters and histograms, OpenTelemetry
+// spans, or anything else. OnRequestStart fires before the request is
+// built and may return a derived context (e.g. one holding a started span);
+// that context is used for the rest of the call. OnRequestEnd always fires
+// once the call finishes, successfully or not.
+type RequestObserver interface {
+	OnRequestStart(ctx context.Context, info RequestInfo) context.Context
+	OnRequestEnd(ctx context.Context, result RequestResult)
+}
+
+// RequestInfo describes a call before it has been made. Operation is the
+// SDK method name (e.g. "RegisterNick"), matching the span names integrators
+// should emit (e.g. "axme.RegisterNick").
+type RequestInfo struct {
+	Operation string
+	Method    string
+	Path      string
+}
+
+// RequestResult describes a finished call, including enough detail to
+// populate duration/size histograms and status-class error counters.
+type RequestResult struct {
+	RequestInfo
+
+	StatusCode     int
+	Err            error
+	Duration       time.Duration
+	RetryCount     int
+	IdempotencyKey string
+	RequestBytes   int
+	ResponseBytes  int
+}
+
+type attemptCounter struct {
+	attempts int
+}
+
+const attemptCounterContextKey contextKey = "axme-attempt-counter"
+
+func withAttemptCounter(ctx context.Context) (context.Context, *attemptCounter) {
+	counter := &attemptCounter{attempts: 1}
+	return context.WithValue(ctx, attemptCounterContextKey, counter), counter
+}
+
+func attemptCounterFromContext(ctx context.Context) (*attemptCounter, bool) {
+	counter, ok := ctx.Value(attemptCounterContextKey).(*attemptCounter)
+	return counter, ok
+}
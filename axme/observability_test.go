@@ -0,0 +1,61 @@
+package axme
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type recordingObserver struct {
+	starts []RequestInfo
+	ends   []RequestResult
+}
+
+func (o *recordingObserver) OnRequestStart(ctx context.Context, info RequestInfo) context.Context {
+	o.starts = append(o.starts, info)
+	return ctx
+}
+
+func (o *recordingObserver) OnRequestEnd(ctx context.Context, result RequestResult) {
+	o.ends = append(o.ends, result)
+}
+
+func TestClientEmitsObserverEvents(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("traceparent"); got != "00-trace-span-01" {
+			t.Fatalf("unexpected traceparent header: %s", got)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	observer := &recordingObserver{}
+	client, err := NewClient(ClientConfig{
+		BaseURL:    server.URL,
+		APIKey:     "token",
+		HTTPClient: server.Client(),
+		Observer:   observer,
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	ctx := WithTraceParent(context.Background(), "00-trace-span-01")
+	if _, err := client.CheckNick(ctx, "@partner.user", RequestOptions{}); err != nil {
+		t.Fatalf("check nick failed: %v", err)
+	}
+
+	if len(observer.starts) != 1 || observer.starts[0].Operation != "CheckNick" {
+		t.Fatalf("unexpected starts: %+v", observer.starts)
+	}
+	if len(observer.ends) != 1 {
+		t.Fatalf("unexpected ends: %+v", observer.ends)
+	}
+	if got := observer.ends[0].StatusCode; got != http.StatusOK {
+		t.Fatalf("unexpected status code: %d", got)
+	}
+	if got := observer.ends[0].RetryCount; got != 1 {
+		t.Fatalf("expected a single attempt, got %d", got)
+	}
+}
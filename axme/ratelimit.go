@@ -0,0 +1,144 @@
+package axme
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimitConfig configures the client-side token-bucket rate limiter,
+// the same shape as client-go's flowcontrol.RateLimiter.
+type RateLimitConfig struct {
+	// QPS is the steady-state rate tokens refill at. Zero (the default)
+	// disables rate limiting.
+	QPS float64
+
+	// Burst is the bucket capacity. It defaults to 1 when QPS is set and
+	// Burst is left at zero.
+	Burst int
+}
+
+// MetricsObserver lets operators track saturation of the client's rate
+// limiter and concurrency semaphore.
+type MetricsObserver interface {
+	// OnAcquired is called every time a request acquires the rate limiter
+	// and (if configured) a concurrency slot.
+	OnAcquired()
+	// OnWaited is called with how long a request waited for a rate limiter
+	// token, whenever that wait was non-zero.
+	OnWaited(d time.Duration)
+	// OnThrottled is called when a 429 response with Retry-After causes the
+	// limiter to slow down subsequent callers.
+	OnThrottled(d time.Duration)
+}
+
+// tokenBucketLimiter is a minimal token-bucket limiter with adaptive
+// backpressure: a 429 response can hold back every token until its
+// Retry-After delay has passed, so subsequent callers throttle without the
+// server having to reject them too.
+type tokenBucketLimiter struct {
+	mu sync.Mutex
+
+	qps   float64
+	burst float64
+
+	tokens         float64
+	lastRefill     time.Time
+	throttledUntil time.Time
+}
+
+func newTokenBucketLimiter(config RateLimitConfig) *tokenBucketLimiter {
+	burst := config.Burst
+	if burst <= 0 {
+		burst = 1
+	}
+	return &tokenBucketLimiter{
+		qps:        config.QPS,
+		burst:      float64(burst),
+		tokens:     float64(burst),
+		lastRefill: time.Now(),
+	}
+}
+
+// Wait blocks until a token is available or ctx is done, returning how long
+// the caller waited (zero when a token was available right away).
+func (l *tokenBucketLimiter) Wait(ctx context.Context) (time.Duration, error) {
+	start := time.Now()
+	blocked := false
+	for {
+		l.mu.Lock()
+		now := time.Now()
+		l.refillLocked(now)
+
+		var wait time.Duration
+		switch {
+		case now.Before(l.throttledUntil):
+			wait = l.throttledUntil.Sub(now)
+		case l.tokens >= 1:
+			l.tokens--
+			l.mu.Unlock()
+			if !blocked {
+				return 0, nil
+			}
+			return time.Since(start), nil
+		case l.qps > 0:
+			wait = time.Duration(float64(time.Second) / l.qps)
+		default:
+			wait = time.Second
+		}
+		l.mu.Unlock()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return time.Since(start), ctx.Err()
+		case <-timer.C:
+			blocked = true
+		}
+	}
+}
+
+func (l *tokenBucketLimiter) refillLocked(now time.Time) {
+	if l.qps <= 0 {
+		return
+	}
+	elapsed := now.Sub(l.lastRefill).Seconds()
+	l.tokens += elapsed * l.qps
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.lastRefill = now
+}
+
+// Throttle feeds a server-observed Retry-After delay back into the limiter
+// so future Wait calls slow down, instead of every caller hammering the
+// server until it starts rejecting them too.
+func (l *tokenBucketLimiter) Throttle(delay time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	until := time.Now().Add(delay)
+	if until.After(l.throttledUntil) {
+		l.throttledUntil = until
+	}
+}
+
+// throttleLimiter parses a 429 response's Retry-After header and, if
+// present, feeds it into limiter and reports it via metrics. It is a no-op
+// when limiter is nil, so callers can invoke it unconditionally. Both
+// requestJSON and retryMiddleware call this for every 429 they see, since
+// under retries requestJSON never observes the intermediate 429s itself.
+func throttleLimiter(limiter *tokenBucketLimiter, metrics MetricsObserver, retryAfter string) {
+	if limiter == nil {
+		return
+	}
+	delay, ok := retryAfterDelay(retryAfter)
+	if !ok {
+		return
+	}
+	limiter.Throttle(delay)
+	if metrics != nil {
+		metrics.OnThrottled(delay)
+	}
+}
@@ -0,0 +1,140 @@
+package axme
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClientEnforcesMaxConcurrent(t *testing.T) {
+	var inFlight int32
+	var maxObserved int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			observed := atomic.LoadInt32(&maxObserved)
+			if current <= observed || atomic.CompareAndSwapInt32(&maxObserved, observed, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientConfig{
+		BaseURL:       server.URL,
+		APIKey:        "token",
+		HTTPClient:    server.Client(),
+		MaxConcurrent: 1,
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	done := make(chan error, 3)
+	for i := 0; i < 3; i++ {
+		go func() {
+			_, err := client.CheckNick(context.Background(), "@partner.user", RequestOptions{})
+			done <- err
+		}()
+	}
+	for i := 0; i < 3; i++ {
+		if err := <-done; err != nil {
+			t.Fatalf("check nick failed: %v", err)
+		}
+	}
+
+	if got := atomic.LoadInt32(&maxObserved); got != 1 {
+		t.Fatalf("expected at most 1 concurrent request, observed %d", got)
+	}
+}
+
+type recordingMetricsObserver struct {
+	throttled int32
+}
+
+func (o *recordingMetricsObserver) OnAcquired()                 {}
+func (o *recordingMetricsObserver) OnWaited(d time.Duration)    {}
+func (o *recordingMetricsObserver) OnThrottled(d time.Duration) { atomic.AddInt32(&o.throttled, 1) }
+
+func TestClientThrottlesLimiterOn429(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	metrics := &recordingMetricsObserver{}
+	client, err := NewClient(ClientConfig{
+		BaseURL:    server.URL,
+		APIKey:     "token",
+		HTTPClient: server.Client(),
+		RateLimit:  RateLimitConfig{QPS: 100, Burst: 5},
+		Metrics:    metrics,
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	if _, err := client.CheckNick(context.Background(), "@partner.user", RequestOptions{}); err == nil {
+		t.Fatalf("expected the first call to surface the 429 as an error")
+	}
+	if _, err := client.CheckNick(context.Background(), "@partner.user", RequestOptions{}); err != nil {
+		t.Fatalf("check nick failed: %v", err)
+	}
+
+	if atomic.LoadInt32(&metrics.throttled) != 1 {
+		t.Fatalf("expected OnThrottled to fire once, got %d", metrics.throttled)
+	}
+}
+
+func TestClientThrottlesLimiterOnNonFinal429sDuringRetries(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) <= 2 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	metrics := &recordingMetricsObserver{}
+	client, err := NewClient(ClientConfig{
+		BaseURL:    server.URL,
+		APIKey:     "token",
+		HTTPClient: server.Client(),
+		RateLimit:  RateLimitConfig{QPS: 100, Burst: 5},
+		Metrics:    metrics,
+		Retry: RetryConfig{
+			MaxAttempts: 3,
+			BaseDelay:   time.Millisecond,
+			MaxDelay:    5 * time.Millisecond,
+		},
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	// The retry middleware absorbs both 429s internally, so requestJSON
+	// only ever sees the final 200 -- the limiter must still learn about
+	// the two 429s it never observes directly.
+	if _, err := client.CheckNick(context.Background(), "@partner.user", RequestOptions{}); err != nil {
+		t.Fatalf("check nick failed: %v", err)
+	}
+
+	if got := atomic.LoadInt32(&metrics.throttled); got != 2 {
+		t.Fatalf("expected OnThrottled to fire twice, got %d", got)
+	}
+}
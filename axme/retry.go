@@ -0,0 +1,250 @@
+package axme
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetryConfig configures automatic retries of transient failures: network
+// errors and HTTPError responses with status 408, 425, 429, or 5xx.
+type RetryConfig struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	// Zero or one (the default) disables retries.
+	MaxAttempts int
+
+	// BaseDelay and MaxDelay bound the exponential backoff between
+	// attempts. They default to 200ms and 10s respectively when zero.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+
+	// ShouldRetry overrides the default retry predicate. resp is nil when
+	// err is non-nil (a transport-level failure), and vice versa.
+	ShouldRetry func(resp *http.Response, err error) bool
+}
+
+// RetryError wraps the final failure of a retried request so callers can
+// distinguish "gave up after N attempts" from a single failed attempt.
+type RetryError struct {
+	Attempts int
+	Err      error
+}
+
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("axme: request failed after %d attempt(s): %v", e.Attempts, e.Err)
+}
+
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
+
+// effectiveBackoffBounds applies RetryConfig's defaults, so both the retry
+// middleware and other backoff users (e.g. the event stream reconnector)
+// agree on what "unset" means.
+func effectiveBackoffBounds(config RetryConfig) (baseDelay, maxDelay time.Duration) {
+	baseDelay = config.BaseDelay
+	if baseDelay <= 0 {
+		baseDelay = 200 * time.Millisecond
+	}
+	maxDelay = config.MaxDelay
+	if maxDelay <= 0 {
+		maxDelay = 10 * time.Second
+	}
+	return baseDelay, maxDelay
+}
+
+func defaultShouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	switch resp.StatusCode {
+	case http.StatusRequestTimeout, http.StatusTooEarly, http.StatusTooManyRequests:
+		return true
+	}
+	return resp.StatusCode >= 500
+}
+
+// retryMiddleware retries the request according to config, rewinding the
+// request body on each attempt via http.Request.GetBody and honoring
+// Retry-After headers (delta-seconds or HTTP-date) when present. It aborts
+// promptly if the request's context is canceled while waiting between
+// attempts. A 429 seen on any attempt, including ones the loop retries
+// internally, is fed into limiter via throttleLimiter so the rate limiter
+// adapts even when requestJSON never sees that response itself.
+func retryMiddleware(config RetryConfig, limiter *tokenBucketLimiter, metrics MetricsObserver) Middleware {
+	if config.MaxAttempts <= 1 {
+		return func(next Doer) Doer {
+			return doerFunc(func(req *http.Request) (*http.Response, error) {
+				perAttemptTimeout := perAttemptTimeoutFromContext(req.Context())
+				if perAttemptTimeout <= 0 {
+					return next.Do(req)
+				}
+				attemptReq, cancelAttempt, err := prepareAttempt(req, 1, perAttemptTimeout)
+				if err != nil {
+					return nil, err
+				}
+				resp, err := next.Do(attemptReq)
+				if err != nil {
+					cancelAttempt()
+					return nil, err
+				}
+				resp.Body = cancelOnClose(resp.Body, cancelAttempt)
+				return resp, nil
+			})
+		}
+	}
+
+	maxAttempts := config.MaxAttempts
+	baseDelay, maxDelay := effectiveBackoffBounds(config)
+	shouldRetry := config.ShouldRetry
+	if shouldRetry == nil {
+		shouldRetry = defaultShouldRetry
+	}
+
+	return func(next Doer) Doer {
+		return doerFunc(func(req *http.Request) (*http.Response, error) {
+			var lastErr error
+			counter, _ := attemptCounterFromContext(req.Context())
+			perAttemptTimeout := perAttemptTimeoutFromContext(req.Context())
+
+			for attempt := 1; attempt <= maxAttempts; attempt++ {
+				if counter != nil {
+					counter.attempts = attempt
+				}
+
+				attemptReq, cancelAttempt, err := prepareAttempt(req, attempt, perAttemptTimeout)
+				if err != nil {
+					return nil, err
+				}
+
+				resp, err := next.Do(attemptReq)
+				if err == nil && !shouldRetry(resp, nil) {
+					resp.Body = cancelOnClose(resp.Body, cancelAttempt)
+					return resp, nil
+				}
+				if err != nil && !shouldRetry(nil, err) {
+					cancelAttempt()
+					return nil, err
+				}
+
+				var retryAfter string
+				if err != nil {
+					lastErr = err
+				} else {
+					retryAfter = resp.Header.Get("Retry-After")
+					lastErr = &HTTPError{StatusCode: resp.StatusCode, Body: drainAndClose(resp)}
+					if resp.StatusCode == http.StatusTooManyRequests {
+						throttleLimiter(limiter, metrics, retryAfter)
+					}
+				}
+				cancelAttempt()
+
+				if attempt == maxAttempts {
+					break
+				}
+
+				delay := backoffWithFullJitter(baseDelay, maxDelay, attempt)
+				if wait, ok := retryAfterDelay(retryAfter); ok {
+					delay = wait
+				}
+
+				select {
+				case <-req.Context().Done():
+					return nil, req.Context().Err()
+				case <-time.After(delay):
+				}
+			}
+
+			return nil, &RetryError{Attempts: maxAttempts, Err: lastErr}
+		})
+	}
+}
+
+// prepareAttempt builds the request to use for a single attempt: a clone
+// with a rewound body (via GetBody) for every attempt after the first, and,
+// when perAttemptTimeout is set, a context scoped to that one attempt
+// rather than the whole retry loop. The returned cancel func must be called
+// once the attempt's response is no longer needed.
+func prepareAttempt(req *http.Request, attempt int, perAttemptTimeout time.Duration) (*http.Request, context.CancelFunc, error) {
+	ctx := req.Context()
+	cancel := func() {}
+	if perAttemptTimeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, perAttemptTimeout)
+	}
+
+	if attempt == 1 && perAttemptTimeout <= 0 {
+		return req, cancel, nil
+	}
+
+	clone := req.Clone(ctx)
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			cancel()
+			return nil, nil, err
+		}
+		clone.Body = body
+	}
+	return clone, cancel, nil
+}
+
+// cancelOnClose wraps body so cancel runs once the caller closes it,
+// releasing a per-attempt timeout context only once its response has been
+// fully read instead of the moment the attempt finishes.
+func cancelOnClose(body io.ReadCloser, cancel context.CancelFunc) io.ReadCloser {
+	return &cancelOnCloseBody{ReadCloser: body, cancel: cancel}
+}
+
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+func drainAndClose(resp *http.Response) string {
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	return string(body)
+}
+
+func backoffWithFullJitter(base, max time.Duration, attempt int) time.Duration {
+	capped := base * time.Duration(int64(1)<<uint(attempt-1))
+	if capped <= 0 || capped > max {
+		capped = max
+	}
+	return time.Duration(rand.Int63n(int64(capped) + 1))
+}
+
+func retryAfterDelay(value string) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			seconds = 0
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if when, err := http.ParseTime(value); err == nil {
+		delay := time.Until(when)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+
+	return 0, false
+}
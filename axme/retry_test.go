@@ -0,0 +1,197 @@
+package axme
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClientRetriesOnServerError(t *testing.T) {
+	var attempts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientConfig{
+		BaseURL:    server.URL,
+		APIKey:     "token",
+		HTTPClient: server.Client(),
+		Retry: RetryConfig{
+			MaxAttempts: 3,
+			BaseDelay:   time.Millisecond,
+			MaxDelay:    5 * time.Millisecond,
+		},
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	if _, err := client.CheckNick(context.Background(), "@partner.user", RequestOptions{}); err != nil {
+		t.Fatalf("check nick failed: %v", err)
+	}
+	if attempts != 3 {
+		t.Fatalf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestClientGivesUpAfterMaxAttempts(t *testing.T) {
+	var attempts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientConfig{
+		BaseURL:    server.URL,
+		APIKey:     "token",
+		HTTPClient: server.Client(),
+		Retry: RetryConfig{
+			MaxAttempts: 2,
+			BaseDelay:   time.Millisecond,
+			MaxDelay:    5 * time.Millisecond,
+		},
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	_, err = client.CheckNick(context.Background(), "@partner.user", RequestOptions{})
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	retryErr, ok := err.(*RetryError)
+	if !ok {
+		t.Fatalf("expected *RetryError, got %T: %v", err, err)
+	}
+	if retryErr.Attempts != 2 {
+		t.Fatalf("expected 2 attempts, got %d", retryErr.Attempts)
+	}
+	if attempts != 2 {
+		t.Fatalf("expected server hit 2 times, got %d", attempts)
+	}
+}
+
+func TestClientRetriesPreservePOSTBodyAndIdempotencyKey(t *testing.T) {
+	var attempts int
+	var bodies []string
+	var idempotencyKeys []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+
+		raw, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatalf("read body: %v", err)
+		}
+		bodies = append(bodies, string(raw))
+		idempotencyKeys = append(idempotencyKeys, r.Header.Get("Idempotency-Key"))
+
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string]any{"ok": true})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientConfig{
+		BaseURL:    server.URL,
+		APIKey:     "token",
+		HTTPClient: server.Client(),
+		Retry: RetryConfig{
+			MaxAttempts: 3,
+			BaseDelay:   time.Millisecond,
+			MaxDelay:    5 * time.Millisecond,
+		},
+	})
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+
+	_, err = client.RegisterNick(
+		context.Background(),
+		RegisterNickRequest{Nick: "@partner.user", DisplayName: "Partner User"},
+		RequestOptions{IdempotencyKey: "register-1"},
+	)
+	if err != nil {
+		t.Fatalf("register nick failed: %v", err)
+	}
+	if attempts != 3 {
+		t.Fatalf("expected 3 attempts, got %d", attempts)
+	}
+
+	for i, body := range bodies {
+		if body != bodies[0] {
+			t.Fatalf("attempt %d sent a different body: %q vs %q", i+1, body, bodies[0])
+		}
+	}
+	for i, key := range idempotencyKeys {
+		if key == "" {
+			t.Fatalf("attempt %d sent no Idempotency-Key", i+1)
+		}
+		if key != "register-1" {
+			t.Fatalf("attempt %d sent a different Idempotency-Key: %q", i+1, key)
+		}
+	}
+}
+
+func TestRetryAfterDelay(t *testing.T) {
+	cases := []struct {
+		name    string
+		value   string
+		wantOK  bool
+		wantMin time.Duration
+		wantMax time.Duration
+	}{
+		{name: "delta seconds", value: "5", wantOK: true, wantMin: 5 * time.Second, wantMax: 5 * time.Second},
+		{name: "zero seconds", value: "0", wantOK: true, wantMin: 0, wantMax: 0},
+		{name: "negative seconds clamp to zero", value: "-5", wantOK: true, wantMin: 0, wantMax: 0},
+		{name: "empty", value: "", wantOK: false},
+		{name: "garbage", value: "not-a-delay", wantOK: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := retryAfterDelay(tc.value)
+			if ok != tc.wantOK {
+				t.Fatalf("retryAfterDelay(%q) ok = %v, want %v", tc.value, ok, tc.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if got < tc.wantMin || got > tc.wantMax {
+				t.Fatalf("retryAfterDelay(%q) = %v, want between %v and %v", tc.value, got, tc.wantMin, tc.wantMax)
+			}
+		})
+	}
+
+	t.Run("http date", func(t *testing.T) {
+		want := 3 * time.Second
+		value := time.Now().Add(want).UTC().Format(http.TimeFormat)
+
+		got, ok := retryAfterDelay(value)
+		if !ok {
+			t.Fatalf("retryAfterDelay(%q) ok = false, want true", value)
+		}
+
+		delta := got - want
+		if delta < 0 {
+			delta = -delta
+		}
+		if delta > time.Second {
+			t.Fatalf("retryAfterDelay(%q) = %v, want close to %v", value, got, want)
+		}
+	})
+}
@@ -0,0 +1,82 @@
+// Package sse implements a minimal parser for the Server-Sent Events wire
+// format (WHATWG "text/event-stream"), enough to drive reconnecting
+// subscriptions: id/event/data fields, blank-line dispatch, and tracking the
+// last event ID seen for Last-Event-ID-based resume.
+package sse
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// Event is a single decoded SSE message. Data joins every "data:" line seen
+// before dispatch with "\n", per the spec.
+type Event struct {
+	ID    string
+	Event string
+	Data  string
+}
+
+// Reader incrementally parses events out of an SSE stream.
+type Reader struct {
+	br          *bufio.Reader
+	lastEventID string
+}
+
+// NewReader wraps r, which is typically an HTTP response body.
+func NewReader(r io.Reader) *Reader {
+	return &Reader{br: bufio.NewReader(r)}
+}
+
+// LastEventID returns the most recent "id:" field seen, for use as the
+// Last-Event-ID header on reconnect.
+func (r *Reader) LastEventID() string {
+	return r.lastEventID
+}
+
+// Next reads and returns the next event, blocking until one is available.
+// It returns the underlying error (io.EOF on a clean close) once the stream
+// is exhausted with no event pending dispatch.
+func (r *Reader) Next() (Event, error) {
+	var event Event
+	var data []string
+	haveField := false
+
+	for {
+		line, readErr := r.br.ReadString('\n')
+		trimmed := strings.TrimRight(line, "\r\n")
+
+		if trimmed != "" {
+			haveField = true
+			field, value, found := strings.Cut(trimmed, ":")
+			if !found {
+				field, value = trimmed, ""
+			}
+			value = strings.TrimPrefix(value, " ")
+
+			switch field {
+			case "event":
+				event.Event = value
+			case "data":
+				data = append(data, value)
+			case "id":
+				event.ID = value
+				r.lastEventID = value
+			}
+		}
+
+		if readErr != nil {
+			if haveField {
+				event.Data = strings.Join(data, "\n")
+				return event, nil
+			}
+			return Event{}, readErr
+		}
+
+		if trimmed == "" && haveField {
+			event.Data = strings.Join(data, "\n")
+			return event, nil
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package sse
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestReaderParsesEvents(t *testing.T) {
+	stream := "id: 1\nevent: nick.renamed\ndata: {\"nick\":\"@a\"}\n\nid: 2\ndata: line one\ndata: line two\n\n"
+	reader := NewReader(strings.NewReader(stream))
+
+	first, err := reader.Next()
+	if err != nil {
+		t.Fatalf("first event: %v", err)
+	}
+	if first.ID != "1" || first.Event != "nick.renamed" || first.Data != `{"nick":"@a"}` {
+		t.Fatalf("unexpected first event: %+v", first)
+	}
+
+	second, err := reader.Next()
+	if err != nil {
+		t.Fatalf("second event: %v", err)
+	}
+	if second.ID != "2" || second.Data != "line one\nline two" {
+		t.Fatalf("unexpected second event: %+v", second)
+	}
+	if reader.LastEventID() != "2" {
+		t.Fatalf("unexpected last event id: %s", reader.LastEventID())
+	}
+
+	if _, err := reader.Next(); err != io.EOF {
+		t.Fatalf("expected io.EOF, got %v", err)
+	}
+}